@@ -3,9 +3,13 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
 	"unicode"
 
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/NSXBet/go-cache-manager/pkg/gocacheoptions"
 )
 
 type Generator struct{}
@@ -69,8 +73,11 @@ func (g *Generator) generateFile(gen *protogen.Plugin, file *protogen.File) erro
 
 	gf.P("import (")
 	gf.P("	\"context\"")
+	gf.P("	\"errors\"")
 	gf.P("	\"fmt\"")
 	gf.P()
+	gf.P("  \"google.golang.org/protobuf/reflect/protoreflect\"")
+	gf.P()
 	gf.P("  \"github.com/NSXBet/go-cache-manager/pkg/gocachemanager\"")
 	gf.P(")")
 
@@ -196,6 +203,19 @@ func (g *Generator) generateService(gf *protogen.GeneratedFile, service *protoge
 	gf.P("}")
 	gf.P()
 
+	gf.P("// Close releases every per-method cache manager's resources (e.g. its invalidation")
+	gf.P("// subscription, if one is configured). Call it during graceful shutdown.")
+	gf.P("func (cm *", managerName, ") Close() error {")
+	gf.P("	return errors.Join(")
+
+	for _, mgr := range constructedManagers {
+		gf.P("		cm.", mgr, ".Close(),")
+	}
+
+	gf.P("	)")
+	gf.P("}")
+	gf.P()
+
 	for _, method := range service.Methods {
 		if merr := g.generateMethod(gf, method); merr != nil {
 			return merr
@@ -228,9 +248,14 @@ func (g *Generator) generateConstructorManager(
 		"{} },",
 		"update", method.GoName, "Fn,",
 		"options...",
-		")",
 	)
 
+	for _, opt := range g.methodCacheOptions(method) {
+		gf.P(opt, ",")
+	}
+
+	gf.P(")")
+
 	gf.P("if err != nil {")
 	gf.P(
 		"	return nil, fmt.Errorf(\"creating cache manager %s: %w\", \"",
@@ -245,6 +270,46 @@ func (g *Generator) generateConstructorManager(
 	return mgrs, nil
 }
 
+// methodCacheOptions reads the gocachemanager method options declared on
+// method (ttl, jitter, singleflight, negative_ttl) and renders them as
+// literal gocachemanager.CacheOption expressions to append to this method's
+// NewCacheManager call.
+func (g *Generator) methodCacheOptions(method *protogen.Method) []string {
+	var opts []string
+
+	methodOptions := method.Desc.Options()
+
+	if ttl, ok := proto.GetExtension(methodOptions, gocacheoptions.Ttl).(string); ok && ttl != "" {
+		if _, err := time.ParseDuration(ttl); err == nil {
+			opts = append(opts, fmt.Sprintf("gocachemanager.WithTTL(gocachemanager.MustParseDuration(%q))", ttl))
+		}
+	}
+
+	if jitter, ok := proto.GetExtension(methodOptions, gocacheoptions.Jitter).(string); ok && jitter != "" {
+		if _, err := time.ParseDuration(jitter); err == nil {
+			opts = append(
+				opts,
+				fmt.Sprintf("gocachemanager.WithJitter(gocachemanager.MustParseDuration(%q))", jitter),
+			)
+		}
+	}
+
+	if singleflight, ok := proto.GetExtension(methodOptions, gocacheoptions.Singleflight).(bool); ok && singleflight {
+		opts = append(opts, "gocachemanager.WithSingleflight()")
+	}
+
+	if negativeTTL, ok := proto.GetExtension(methodOptions, gocacheoptions.NegativeTTL).(string); ok && negativeTTL != "" {
+		if _, err := time.ParseDuration(negativeTTL); err == nil {
+			opts = append(
+				opts,
+				fmt.Sprintf("gocachemanager.WithNegativeTTL(gocachemanager.MustParseDuration(%q))", negativeTTL),
+			)
+		}
+	}
+
+	return opts
+}
+
 func (g *Generator) generateMethod(gf *protogen.GeneratedFile, method *protogen.Method) error {
 	managerName := g.managerName(method.Parent.GoName)
 	fieldName := g.methodName(g.privateManagerName(method.GoName), method.GoName)
@@ -304,5 +369,100 @@ func (g *Generator) generateMethod(gf *protogen.GeneratedFile, method *protogen.
 	gf.P("}")
 	gf.P()
 
+	// GetMany cache
+	gf.P(
+		"// GetMany", method.GoName, " batches ", method.GoName,
+		" lookups, reading through the cache with a single pipelined call per",
+	)
+	gf.P("// backend and fetching only the misses, fanned out across a bounded worker pool.")
+	gf.P(
+		"// A single input's failure, including an ordinary not-found, never fails the rest of the",
+	)
+	gf.P("// batch: errs[i] reports it, parallel to inputs and outputs.")
+	gf.P(
+		"func (cm *",
+		managerName,
+		") GetMany",
+		method.GoName,
+		"(",
+	)
+	gf.P("  ctx context.Context,")
+	gf.P("	inputs []*", method.Input.GoIdent.GoName, ",")
+	gf.P(") (outputs []*", method.Output.GoIdent.GoName, ", errs []error, err error) {")
+	gf.P("	return cm.", fieldName, ".GetMany(ctx, inputs)")
+	gf.P("}")
+	gf.P()
+
+	// Prefetch cache
+	gf.P(
+		"// Prefetch", method.GoName, " warms the cache for a batch of ", method.GoName,
+		" inputs without returning their outputs. A per-input not-found is expected and ignored;",
+	)
+	gf.P("// any other per-input error, or a batch-wide failure, is returned.")
+	gf.P(
+		"func (cm *",
+		managerName,
+		") Prefetch",
+		method.GoName,
+		"(",
+	)
+	gf.P("  ctx context.Context,")
+	gf.P("	inputs []*", method.Input.GoIdent.GoName, ",")
+	gf.P(") error {")
+	gf.P("	_, errs, err := cm.", fieldName, ".GetMany(ctx, inputs)")
+	gf.P("	if err != nil {")
+	gf.P("		return err")
+	gf.P("	}")
+	gf.P()
+	gf.P("	for _, itemErr := range errs {")
+	gf.P("		if itemErr != nil && !errors.Is(itemErr, gocachemanager.ErrNotFound) {")
+	gf.P("			return itemErr")
+	gf.P("		}")
+	gf.P("	}")
+	gf.P()
+	gf.P("	return nil")
+	gf.P("}")
+	gf.P()
+
+	// Invalidate cache
+	gf.P(
+		"// Invalidate", method.GoName,
+		" evicts input's entry from every backend and, if an invalidation",
+	)
+	gf.P("// channel is configured, notifies other replicas to do the same.")
+	gf.P(
+		"func (cm *",
+		managerName,
+		") Invalidate",
+		method.GoName,
+		"(",
+	)
+	gf.P("  ctx context.Context,")
+	gf.P("	input *", method.Input.GoIdent.GoName, ",")
+	gf.P(") error {")
+	gf.P("	return cm.", fieldName, ".Invalidate(ctx, input)")
+	gf.P("}")
+	gf.P()
+
+	// KeyExcludingFields
+	gf.P(
+		"// ", method.GoName, "KeyExcludingFields derives a key for input with the given proto",
+		" field numbers left out of the hash.",
+	)
+	gf.P("// It equals the key ", method.GoName, " uses for input only when none of those fields is set on input.")
+	gf.P(
+		"func (cm *",
+		managerName,
+		") ",
+		method.GoName,
+		"KeyExcludingFields(",
+	)
+	gf.P("	input *", method.Input.GoIdent.GoName, ",")
+	gf.P("	exclude ...protoreflect.FieldNumber,")
+	gf.P(") (string, error) {")
+	gf.P("	return cm.", fieldName, ".KeyExcludingFields(input, exclude...)")
+	gf.P("}")
+	gf.P()
+
 	return nil
 }
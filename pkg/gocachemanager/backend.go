@@ -0,0 +1,84 @@
+package gocachemanager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Backend is the interface every cache store must implement to be usable by a
+// CacheManager. Backends are intentionally byte-oriented so that the codec
+// used to encode/decode values is decided by the CacheManager, not the store.
+type Backend interface {
+	// Get returns the raw value for key. The second return value is false if
+	// the key is not present (or has expired).
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores value under key with the given TTL. A ttl of zero means the
+	// value never expires.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes key from the backend. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// Has reports whether key is present (and not expired) without fetching its value.
+	Has(ctx context.Context, key string) (bool, error)
+
+	// Name identifies this backend's kind (e.g. "memory", "redis"), used to
+	// label metrics and trace attributes.
+	Name() string
+}
+
+// BulkBackend is an optional extension of Backend for stores that can
+// pipeline multiple reads/writes (e.g. Redis MGET/MSET). CacheManager.GetMany
+// and SetMany use it when a backend implements it, falling back to one
+// Get/Set call per key otherwise.
+type BulkBackend interface {
+	Backend
+
+	// GetMany returns the raw values for the keys that are present (and not
+	// expired); keys with no entry are simply absent from the result.
+	GetMany(ctx context.Context, keys []string) (map[string][]byte, error)
+
+	// SetMany stores every key/value pair with the given TTL.
+	SetMany(ctx context.Context, values map[string][]byte, ttl time.Duration) error
+}
+
+// BackendFactory builds a Backend from a parsed backend URI. See RegisterBackend.
+type BackendFactory func(uri string) (Backend, error)
+
+var (
+	backendRegistryMu sync.RWMutex
+	backendRegistry   = map[string]BackendFactory{}
+)
+
+// RegisterBackend makes a Backend implementation available under the given
+// URI scheme (e.g. "badger"), so it can be selected via NewFromURI without
+// gocachemanager needing to depend on it directly. Registering the same
+// scheme twice overwrites the previous factory.
+func RegisterBackend(scheme string, factory BackendFactory) {
+	backendRegistryMu.Lock()
+	defer backendRegistryMu.Unlock()
+
+	backendRegistry[scheme] = factory
+}
+
+func lookupBackendFactory(scheme string) (BackendFactory, error) {
+	backendRegistryMu.RLock()
+	defer backendRegistryMu.RUnlock()
+
+	factory, ok := backendRegistry[scheme]
+	if !ok {
+		return nil, fmt.Errorf("no backend registered for scheme %q", scheme)
+	}
+
+	return factory, nil
+}
+
+func init() {
+	RegisterBackend("memory", newMemoryBackendFromURI)
+	RegisterBackend("redis", newRedisBackendFromURI)
+	RegisterBackend("memcache", newMemcacheBackendFromURI)
+	RegisterBackend("tiered", newTieredBackendFromURI)
+}
@@ -0,0 +1,96 @@
+package gocachemanager
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+)
+
+// memcacheBackend is a Backend implementation on top of one or more memcached
+// servers.
+type memcacheBackend struct {
+	client *memcache.Client
+}
+
+// newMemcacheBackendFromURI parses URIs of the form "memcache://h1,h2,h3",
+// where the host list is the set of memcached servers to pool across.
+func newMemcacheBackendFromURI(uri string) (Backend, error) {
+	hosts := strings.TrimPrefix(uri, "memcache://")
+	if hosts == "" {
+		return nil, errors.New("memcache backend requires at least one host")
+	}
+
+	return &memcacheBackend{client: memcache.New(strings.Split(hosts, ",")...)}, nil
+}
+
+func (b *memcacheBackend) Get(_ context.Context, key string) ([]byte, bool, error) {
+	item, err := b.client.Get(key)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return item.Value, true, nil
+}
+
+func (b *memcacheBackend) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	return b.client.Set(&memcache.Item{
+		Key:        key,
+		Value:      value,
+		Expiration: int32(ttl.Seconds()),
+	})
+}
+
+func (b *memcacheBackend) Delete(_ context.Context, key string) error {
+	err := b.client.Delete(key)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return nil
+	}
+
+	return err
+}
+
+func (b *memcacheBackend) Has(ctx context.Context, key string) (bool, error) {
+	_, ok, err := b.Get(ctx, key)
+	return ok, err
+}
+
+func (b *memcacheBackend) Name() string {
+	return "memcache"
+}
+
+// GetMany uses memcached's multi-get protocol support.
+func (b *memcacheBackend) GetMany(_ context.Context, keys []string) (map[string][]byte, error) {
+	items, err := b.client.GetMulti(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string][]byte, len(items))
+	for key, item := range items {
+		found[key] = item.Value
+	}
+
+	return found, nil
+}
+
+// SetMany issues one Set per key: gomemcache has no multi-set command.
+func (b *memcacheBackend) SetMany(_ context.Context, values map[string][]byte, ttl time.Duration) error {
+	for key, value := range values {
+		if err := b.client.Set(&memcache.Item{
+			Key:        key,
+			Value:      value,
+			Expiration: int32(ttl.Seconds()),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
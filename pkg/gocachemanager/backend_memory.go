@@ -0,0 +1,112 @@
+package gocachemanager
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryBackend is an in-process Backend backed by a mutex-guarded map. It is
+// the default backend used when no other is configured.
+type memoryBackend struct {
+	mu      sync.RWMutex
+	entries map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	value    []byte
+	expireAt time.Time
+}
+
+func (e memoryEntry) expired() bool {
+	return !e.expireAt.IsZero() && time.Now().After(e.expireAt)
+}
+
+// newMemoryBackend creates an empty in-memory Backend.
+func newMemoryBackend() *memoryBackend {
+	return &memoryBackend{
+		entries: make(map[string]memoryEntry),
+	}
+}
+
+// newMemoryBackendFromURI ignores the URI host/query: "memory://" carries no
+// configuration today.
+func newMemoryBackendFromURI(_ string) (Backend, error) {
+	return newMemoryBackend(), nil
+}
+
+func (b *memoryBackend) Get(_ context.Context, key string) ([]byte, bool, error) {
+	b.mu.RLock()
+	entry, ok := b.entries[key]
+	b.mu.RUnlock()
+
+	if !ok || entry.expired() {
+		return nil, false, nil
+	}
+
+	return entry.value, true, nil
+}
+
+func (b *memoryBackend) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	var expireAt time.Time
+	if ttl > 0 {
+		expireAt = time.Now().Add(ttl)
+	}
+
+	b.mu.Lock()
+	b.entries[key] = memoryEntry{value: value, expireAt: expireAt}
+	b.mu.Unlock()
+
+	return nil
+}
+
+func (b *memoryBackend) Delete(_ context.Context, key string) error {
+	b.mu.Lock()
+	delete(b.entries, key)
+	b.mu.Unlock()
+
+	return nil
+}
+
+func (b *memoryBackend) Has(ctx context.Context, key string) (bool, error) {
+	_, ok, err := b.Get(ctx, key)
+	return ok, err
+}
+
+func (b *memoryBackend) Name() string {
+	return "memory"
+}
+
+func (b *memoryBackend) GetMany(_ context.Context, keys []string) (map[string][]byte, error) {
+	found := make(map[string][]byte, len(keys))
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, key := range keys {
+		entry, ok := b.entries[key]
+		if !ok || entry.expired() {
+			continue
+		}
+
+		found[key] = entry.value
+	}
+
+	return found, nil
+}
+
+func (b *memoryBackend) SetMany(_ context.Context, values map[string][]byte, ttl time.Duration) error {
+	var expireAt time.Time
+	if ttl > 0 {
+		expireAt = time.Now().Add(ttl)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for key, value := range values {
+		b.entries[key] = memoryEntry{value: value, expireAt: expireAt}
+	}
+
+	return nil
+}
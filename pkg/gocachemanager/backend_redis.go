@@ -0,0 +1,131 @@
+package gocachemanager
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisBackend is a Backend implementation on top of a single Redis instance.
+type redisBackend struct {
+	client *redis.Client
+}
+
+// newRedisBackend builds a redisBackend from an already configured client, so
+// callers that need advanced options (TLS, sentinel, ...) can still plug in.
+func newRedisBackend(client *redis.Client) *redisBackend {
+	return &redisBackend{client: client}
+}
+
+// newRedisBackendFromURI parses URIs of the form
+// "redis://host:port?db=0&password=...".
+func newRedisBackendFromURI(uri string) (Backend, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &redis.Options{
+		Addr: parsed.Host,
+	}
+
+	query := parsed.Query()
+
+	if db := query.Get("db"); db != "" {
+		dbIndex, err := strconv.Atoi(db)
+		if err != nil {
+			return nil, err
+		}
+
+		opts.DB = dbIndex
+	}
+
+	if password := query.Get("password"); password != "" {
+		opts.Password = password
+	}
+
+	return newRedisBackend(redis.NewClient(opts)), nil
+}
+
+func (b *redisBackend) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := b.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+func (b *redisBackend) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return b.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (b *redisBackend) Delete(ctx context.Context, key string) error {
+	return b.client.Del(ctx, key).Err()
+}
+
+func (b *redisBackend) Has(ctx context.Context, key string) (bool, error) {
+	exists, err := b.client.Exists(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return exists > 0, nil
+}
+
+func (b *redisBackend) Name() string {
+	return "redis"
+}
+
+// redisClient exposes the underlying client so a redisInvalidationTransport
+// can be derived from an already-configured redis:// backend.
+func (b *redisBackend) redisClient() *redis.Client {
+	return b.client
+}
+
+// GetMany pipelines a single MGET for all keys.
+func (b *redisBackend) GetMany(ctx context.Context, keys []string) (map[string][]byte, error) {
+	values, err := b.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string][]byte, len(keys))
+
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+
+		str, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		found[keys[i]] = []byte(str)
+	}
+
+	return found, nil
+}
+
+// SetMany pipelines a SET per key (Redis' MSET has no per-key TTL, so each
+// entry still needs its own command).
+func (b *redisBackend) SetMany(ctx context.Context, values map[string][]byte, ttl time.Duration) error {
+	pipe := b.client.Pipeline()
+
+	for key, value := range values {
+		pipe.Set(ctx, key, value, ttl)
+	}
+
+	_, err := pipe.Exec(ctx)
+
+	return err
+}
@@ -0,0 +1,210 @@
+package gocachemanager
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// tieredBackend composes an ordered list of backends into a single Backend,
+// reading from the fastest tier first and backfilling earlier tiers on a hit
+// further down the chain.
+type tieredBackend struct {
+	tiers []Backend
+}
+
+// newTieredBackend wraps an ordered list of backends, from fastest to slowest.
+func newTieredBackend(tiers ...Backend) *tieredBackend {
+	return &tieredBackend{tiers: tiers}
+}
+
+// tieredSplitRe matches a comma that starts a new backend URI (i.e. is
+// immediately followed by a scheme), so that backends whose own URI
+// contains commas (e.g. memcache host lists) aren't split apart. Go's
+// regexp (RE2) doesn't support lookahead, so the scheme is part of the
+// match rather than asserted past it; splitTieredSpecs splits just before
+// the match instead of consuming it.
+var tieredSplitRe = regexp.MustCompile(`,[a-zA-Z][a-zA-Z0-9+.-]*://`)
+
+// splitTieredSpecs splits body on commas that start a new backend URI, per
+// tieredSplitRe.
+func splitTieredSpecs(body string) []string {
+	matches := tieredSplitRe.FindAllStringIndex(body, -1)
+	specs := make([]string, 0, len(matches)+1)
+
+	start := 0
+
+	for _, m := range matches {
+		specs = append(specs, body[start:m[0]])
+		start = m[0] + 1 // skip the comma; keep the scheme for the next spec
+	}
+
+	return append(specs, body[start:])
+}
+
+// newTieredBackendFromURI parses URIs of the form
+// "tiered://memory,redis://host:port", building each tier via NewFromURI.
+func newTieredBackendFromURI(uri string) (Backend, error) {
+	body := strings.TrimPrefix(uri, "tiered://")
+
+	var tiers []Backend
+
+	for _, spec := range splitTieredSpecs(body) {
+		if !strings.Contains(spec, "://") {
+			spec += "://"
+		}
+
+		tier, err := NewFromURI(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		tiers = append(tiers, tier)
+	}
+
+	return newTieredBackend(tiers...), nil
+}
+
+func (b *tieredBackend) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	for i, tier := range b.tiers {
+		value, ok, err := tier.Get(ctx, key)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		for _, earlier := range b.tiers[:i] {
+			_ = earlier.Set(ctx, key, value, 0)
+		}
+
+		return value, true, nil
+	}
+
+	return nil, false, nil
+}
+
+func (b *tieredBackend) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	for _, tier := range b.tiers {
+		if err := tier.Set(ctx, key, value, ttl); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *tieredBackend) Delete(ctx context.Context, key string) error {
+	for _, tier := range b.tiers {
+		if err := tier.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *tieredBackend) Has(ctx context.Context, key string) (bool, error) {
+	for _, tier := range b.tiers {
+		ok, err := tier.Has(ctx, key)
+		if err != nil {
+			return false, err
+		}
+
+		if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (b *tieredBackend) Name() string {
+	return "tiered"
+}
+
+// GetMany reads through each tier in order, only asking later tiers for keys
+// still missing, and backfilling earlier tiers on a hit found further down.
+func (b *tieredBackend) GetMany(ctx context.Context, keys []string) (map[string][]byte, error) {
+	found := make(map[string][]byte, len(keys))
+	pending := keys
+
+	for i, tier := range b.tiers {
+		if len(pending) == 0 {
+			break
+		}
+
+		tierFound, err := getMany(ctx, tier, pending)
+		if err != nil {
+			return nil, err
+		}
+
+		var stillPending []string
+
+		for _, key := range pending {
+			if value, ok := tierFound[key]; ok {
+				found[key] = value
+			} else {
+				stillPending = append(stillPending, key)
+			}
+		}
+
+		if len(tierFound) > 0 {
+			if err := setMany(ctx, b.tiers[:i], tierFound, 0); err != nil {
+				return nil, err
+			}
+		}
+
+		pending = stillPending
+	}
+
+	return found, nil
+}
+
+func (b *tieredBackend) SetMany(ctx context.Context, values map[string][]byte, ttl time.Duration) error {
+	return setMany(ctx, b.tiers, values, ttl)
+}
+
+func getMany(ctx context.Context, backend Backend, keys []string) (map[string][]byte, error) {
+	if bulk, ok := backend.(BulkBackend); ok {
+		return bulk.GetMany(ctx, keys)
+	}
+
+	found := make(map[string][]byte, len(keys))
+
+	for _, key := range keys {
+		value, ok, err := backend.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			found[key] = value
+		}
+	}
+
+	return found, nil
+}
+
+func setMany(ctx context.Context, backends []Backend, values map[string][]byte, ttl time.Duration) error {
+	for _, backend := range backends {
+		if bulk, ok := backend.(BulkBackend); ok {
+			if err := bulk.SetMany(ctx, values, ttl); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		for key, value := range values {
+			if err := backend.Set(ctx, key, value, ttl); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package gocachemanager
+
+import "testing"
+
+func TestTieredSplitRe(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "simple two tiers",
+			body: "memory,redis://host:1234",
+			want: []string{"memory", "redis://host:1234"},
+		},
+		{
+			name: "memcache host list is not split apart",
+			body: "memory,memcache://host1:11211,host2:11211",
+			want: []string{"memory", "memcache://host1:11211,host2:11211"},
+		},
+		{
+			name: "three tiers, last one a host list",
+			body: "memory,redis://a:1,memcache://b:2,c:3",
+			want: []string{"memory", "redis://a:1", "memcache://b:2,c:3"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := splitTieredSpecs(tc.body)
+
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %d parts %v, want %d parts %v", len(got), got, len(tc.want), tc.want)
+			}
+
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("part %d = %q, want %q", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNewTieredBackendFromURI(t *testing.T) {
+	backend, err := newTieredBackendFromURI("tiered://memory,redis://localhost:6379")
+	if err != nil {
+		t.Fatalf("newTieredBackendFromURI: %v", err)
+	}
+
+	tiered, ok := backend.(*tieredBackend)
+	if !ok {
+		t.Fatalf("expected *tieredBackend, got %T", backend)
+	}
+
+	if len(tiered.tiers) != 2 {
+		t.Fatalf("expected 2 tiers, got %d", len(tiered.tiers))
+	}
+
+	if got := tiered.tiers[0].Name(); got != "memory" {
+		t.Errorf("tier 0 name = %q, want memory", got)
+	}
+
+	if got := tiered.tiers[1].Name(); got != "redis" {
+		t.Errorf("tier 1 name = %q, want redis", got)
+	}
+}
@@ -0,0 +1,222 @@
+package gocachemanager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// maxPrefetchWorkers bounds how many concurrent update-function calls a
+// GetMany/Prefetch can issue, so a large batch doesn't open unbounded
+// concurrent requests against the upstream.
+const maxPrefetchWorkers = 16
+
+// GetMany returns the cached output for every input, in the same order,
+// reading through the backend chain with a single pipelined call per
+// backend where supported (see BulkBackend) and fetching only the misses
+// from the update function, fanned out across a bounded worker pool. Misses
+// are backfilled into every backend in a single pipelined write.
+//
+// A single input's failure - including an ordinary ErrNotFound for a
+// negatively-cached entry - never aborts the rest of the batch: it is
+// reported in the returned errs slice, one entry per input, with errs[i]
+// nil and outputs[i] populated on success. The third return value is only
+// non-nil for a batch-wide failure (key derivation, a full backend read, or
+// the pipelined backfill write). On a key derivation or backend read failure
+// outputs and errs are nil; on a backfill write failure the outputs and errs
+// already gathered are still returned alongside it.
+func (cm *CacheManager[I, O]) GetMany(ctx context.Context, inputs []I) ([]O, []error, error) {
+	keys := make([]string, len(inputs))
+
+	for i, input := range inputs {
+		key, err := cm.key(input)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		keys[i] = key
+	}
+
+	raw, err := cm.getManyRaw(ctx, keys)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading %s from cache: %w", cm.name, err)
+	}
+
+	outputs := make([]O, len(inputs))
+	errs := make([]error, len(inputs))
+
+	var missing []int
+
+	for i, key := range keys {
+		value, ok := raw[key]
+		if !ok {
+			missing = append(missing, i)
+			continue
+		}
+
+		if bytes.Equal(value, negativeSentinel) {
+			errs[i] = ErrNotFound
+			cm.instr.recordHit(ctx, "cache")
+
+			continue
+		}
+
+		output := cm.zeroOutput()
+		if err := cm.settings.codec.Unmarshal(value, output); err != nil {
+			errs[i] = fmt.Errorf("decoding cached %s: %w", cm.name, err)
+			continue
+		}
+
+		outputs[i] = output
+		cm.instr.recordHit(ctx, "cache")
+	}
+
+	if len(missing) == 0 {
+		return outputs, errs, nil
+	}
+
+	if err := cm.fillMisses(ctx, inputs, keys, outputs, errs, missing); err != nil {
+		return outputs, errs, err
+	}
+
+	return outputs, errs, nil
+}
+
+// SetMany stores the given input/output pairs in every configured backend,
+// pipelining the write where the backend supports it (see BulkBackend).
+func (cm *CacheManager[I, O]) SetMany(ctx context.Context, inputs []I, outputs []O) error {
+	if len(inputs) != len(outputs) {
+		return fmt.Errorf("setting many %s: %d inputs but %d outputs", cm.name, len(inputs), len(outputs))
+	}
+
+	values := make(map[string][]byte, len(inputs))
+
+	for i, input := range inputs {
+		key, err := cm.key(input)
+		if err != nil {
+			return err
+		}
+
+		raw, err := cm.settings.codec.Marshal(outputs[i])
+		if err != nil {
+			return fmt.Errorf("encoding %s for cache: %w", cm.name, err)
+		}
+
+		values[key] = raw
+	}
+
+	return cm.setManyRaw(ctx, values, cm.jitteredTTL())
+}
+
+// fillMisses calls the update function for every index in missing, fanned
+// out across a bounded worker pool. Each index fails independently into
+// errs[idx]; a failure for one input never prevents another input's result
+// from being written to outputs or from being backfilled into the backends.
+func (cm *CacheManager[I, O]) fillMisses(
+	ctx context.Context,
+	inputs []I,
+	keys []string,
+	outputs []O,
+	errs []error,
+	missing []int,
+) error {
+	cm.instr.recordMiss(ctx)
+
+	var (
+		wg    sync.WaitGroup
+		sem   = make(chan struct{}, maxPrefetchWorkers)
+		mu    sync.Mutex
+		fresh = make(map[string][]byte)
+	)
+
+	for _, idx := range missing {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(idx int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			output, err := cm.refreshOne(ctx, keys[idx], inputs[idx])
+			if err != nil {
+				mu.Lock()
+				errs[idx] = err
+				mu.Unlock()
+
+				return
+			}
+
+			raw, err := cm.settings.codec.Marshal(output)
+			if err != nil {
+				mu.Lock()
+				errs[idx] = fmt.Errorf("encoding %s for cache: %w", cm.name, err)
+				mu.Unlock()
+
+				return
+			}
+
+			mu.Lock()
+			outputs[idx] = output
+			fresh[keys[idx]] = raw
+			mu.Unlock()
+		}(idx)
+	}
+
+	wg.Wait()
+
+	if len(fresh) == 0 {
+		return nil
+	}
+
+	return cm.setManyRaw(ctx, fresh, cm.jitteredTTL())
+}
+
+// getManyRaw reads through each backend in order, only asking later backends
+// for keys still missing, and backfilling earlier backends on a hit found
+// further down the chain - the same approach as tieredBackend.GetMany.
+func (cm *CacheManager[I, O]) getManyRaw(ctx context.Context, keys []string) (map[string][]byte, error) {
+	found := make(map[string][]byte, len(keys))
+	pending := keys
+
+	for i, backend := range cm.settings.backends {
+		if len(pending) == 0 {
+			break
+		}
+
+		tierFound, err := getMany(ctx, backend, pending)
+		if err != nil {
+			return nil, err
+		}
+
+		var stillPending []string
+
+		for _, key := range pending {
+			if value, ok := tierFound[key]; ok {
+				found[key] = value
+			} else {
+				stillPending = append(stillPending, key)
+			}
+		}
+
+		if len(tierFound) > 0 {
+			if err := setMany(ctx, cm.settings.backends[:i], tierFound, 0); err != nil {
+				return nil, err
+			}
+		}
+
+		pending = stillPending
+	}
+
+	return found, nil
+}
+
+func (cm *CacheManager[I, O]) setManyRaw(ctx context.Context, values map[string][]byte, ttl time.Duration) error {
+	if err := setMany(ctx, cm.settings.backends, values, ttl); err != nil {
+		cm.instr.recordError(ctx, "set_many")
+		return fmt.Errorf("writing %s to cache: %w", cm.name, err)
+	}
+
+	return nil
+}
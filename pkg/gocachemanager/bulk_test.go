@@ -0,0 +1,134 @@
+package gocachemanager
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestGetMany_IsolatesPerItemFailures(t *testing.T) {
+	var calls int32
+
+	cm, err := NewCacheManager[*wrapperspb.StringValue, *wrapperspb.StringValue](
+		"getmany-isolate",
+		func() *wrapperspb.StringValue { return &wrapperspb.StringValue{} },
+		func(_ context.Context, input *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+			atomic.AddInt32(&calls, 1)
+
+			if input.GetValue() == "missing" {
+				return nil, ErrNotFound
+			}
+
+			return &wrapperspb.StringValue{Value: "got:" + input.GetValue()}, nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("NewCacheManager: %v", err)
+	}
+
+	inputs := []*wrapperspb.StringValue{
+		{Value: "a"},
+		{Value: "missing"},
+		{Value: "b"},
+	}
+
+	outputs, errs, err := cm.GetMany(context.Background(), inputs)
+	if err != nil {
+		t.Fatalf("GetMany: %v", err)
+	}
+
+	if errs[0] != nil {
+		t.Errorf("errs[0] = %v, want nil", errs[0])
+	}
+
+	if outputs[0].GetValue() != "got:a" {
+		t.Errorf("outputs[0] = %q, want got:a", outputs[0].GetValue())
+	}
+
+	if !errors.Is(errs[1], ErrNotFound) {
+		t.Errorf("errs[1] = %v, want ErrNotFound", errs[1])
+	}
+
+	if errs[2] != nil {
+		t.Errorf("errs[2] = %v, want nil", errs[2])
+	}
+
+	if outputs[2].GetValue() != "got:b" {
+		t.Errorf("outputs[2] = %q, want got:b", outputs[2].GetValue())
+	}
+
+	// The successful misses must have been persisted despite the one
+	// failure in the same batch: a second GetMany for the same inputs
+	// should read them from cache instead of calling updateFn again.
+	before := atomic.LoadInt32(&calls)
+
+	if _, _, err := cm.GetMany(context.Background(), inputs); err != nil {
+		t.Fatalf("second GetMany: %v", err)
+	}
+
+	// "missing" has no negativeTTL configured, so it alone is never
+	// positively cached and calls updateFn again; "a" and "b" must not.
+	if got := atomic.LoadInt32(&calls) - before; got != 1 {
+		t.Errorf("updateFn called %d more times on the second GetMany, want 1 (only the uncached miss)", got)
+	}
+}
+
+func TestGetMany_BackfillsEarlierBackendOnHit(t *testing.T) {
+	slow := newMemoryBackend()
+
+	cm, err := NewCacheManager[*wrapperspb.StringValue, *wrapperspb.StringValue](
+		"getmany-backfill",
+		func() *wrapperspb.StringValue { return &wrapperspb.StringValue{} },
+		func(_ context.Context, _ *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+			t.Fatal("updateFn should not be called; the slow backend already has the value")
+			return nil, nil
+		},
+		WithBackend(slow),
+	)
+	if err != nil {
+		t.Fatalf("NewCacheManager: %v", err)
+	}
+
+	input := &wrapperspb.StringValue{Value: "x"}
+
+	key, err := cm.key(input)
+	if err != nil {
+		t.Fatalf("key: %v", err)
+	}
+
+	raw, err := cm.settings.codec.Marshal(&wrapperspb.StringValue{Value: "from-slow-tier"})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	if err := slow.Set(context.Background(), key, raw, 0); err != nil {
+		t.Fatalf("slow.Set: %v", err)
+	}
+
+	outputs, errs, err := cm.GetMany(context.Background(), []*wrapperspb.StringValue{input})
+	if err != nil {
+		t.Fatalf("GetMany: %v", err)
+	}
+
+	if errs[0] != nil {
+		t.Fatalf("errs[0] = %v, want nil", errs[0])
+	}
+
+	if outputs[0].GetValue() != "from-slow-tier" {
+		t.Errorf("outputs[0] = %q, want from-slow-tier", outputs[0].GetValue())
+	}
+
+	memory := cm.settings.backends[0]
+
+	ok, err := memory.Has(context.Background(), key)
+	if err != nil {
+		t.Fatalf("memory.Has: %v", err)
+	}
+
+	if !ok {
+		t.Fatalf("GetMany did not backfill the in-memory tier on a hit from the slow tier")
+	}
+}
@@ -0,0 +1,479 @@
+package gocachemanager
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/NSXBet/go-cache-manager/pkg/keyer"
+)
+
+// CacheManager caches the result of a single RPC-shaped function: given an
+// input I, it returns an output O, consulting an ordered list of Backends
+// before falling back to updateFn on a miss. I and O are usually generated
+// protobuf types, but any type supported by the configured Codec works.
+type CacheManager[I any, O any] struct {
+	name         string
+	zeroOutput   func() O
+	updateFn     func(context.Context, I) (O, error)
+	settings     *CacheSettings
+	flight       singleflight.Group
+	instr        *instrumentation
+	invalidation *invalidationConfig
+}
+
+// NewCacheManager builds a CacheManager for a single cached method. name
+// namespaces the keys for this manager (typically the method name).
+// zeroOutput must return a fresh, empty O so responses can be unmarshaled
+// into it. updateFn is called to populate the cache on a miss or Refresh.
+func NewCacheManager[I any, O any](
+	name string,
+	zeroOutput func() O,
+	updateFn func(context.Context, I) (O, error),
+	options ...CacheOption,
+) (*CacheManager[I, O], error) {
+	settings := DefaultCacheSettings()
+
+	for _, option := range options {
+		option(settings)
+	}
+
+	// Preserve the historical "in-memory first, then whatever else was
+	// configured" tiering unless the caller explicitly opts out.
+	if !settings.skipInMemoryCache {
+		settings.backends = append([]Backend{newMemoryBackend()}, settings.backends...)
+	}
+
+	if len(settings.backends) == 0 {
+		settings.backends = []Backend{newMemoryBackend()}
+	}
+
+	if settings.codec == nil {
+		settings.codec = defaultCodec(zeroOutput())
+	}
+
+	instr, err := newInstrumentation(name, settings)
+	if err != nil {
+		return nil, fmt.Errorf("setting up instrumentation for %s: %w", name, err)
+	}
+
+	cm := &CacheManager[I, O]{
+		name:       name,
+		zeroOutput: zeroOutput,
+		updateFn:   updateFn,
+		settings:   settings,
+		instr:      instr,
+	}
+
+	if settings.invalidationChannel != "" {
+		if err := cm.subscribeInvalidation(); err != nil {
+			return nil, fmt.Errorf("subscribing %s to invalidation channel: %w", name, err)
+		}
+	}
+
+	return cm, nil
+}
+
+// Close releases resources held by the manager. Currently that's only the
+// invalidation subscription, when WithInvalidationChannel is configured: it
+// stops the transport's background delivery goroutine and closes its pub/sub
+// connection. It's a no-op otherwise. Call it when the manager is no longer
+// needed, e.g. during graceful shutdown.
+func (cm *CacheManager[I, O]) Close() error {
+	if cm.invalidation == nil {
+		return nil
+	}
+
+	return cm.invalidation.unsubscribe()
+}
+
+// subscribeInvalidation resolves the invalidation transport, subscribes to
+// the configured channel, and evicts this manager's local in-memory entries
+// as invalidation messages for them arrive.
+func (cm *CacheManager[I, O]) subscribeInvalidation() error {
+	transport, err := resolveInvalidationTransport(cm.settings)
+	if err != nil {
+		return err
+	}
+
+	unsubscribe, err := transport.Subscribe(
+		context.Background(),
+		cm.settings.invalidationChannel,
+		func(msg string) {
+			origin, key := decodeInvalidationMessage(msg)
+			if origin == replicaID {
+				return
+			}
+
+			if strings.HasPrefix(key, cm.namespace()+":") {
+				cm.evictLocal(context.Background(), key)
+			}
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	cm.invalidation = &invalidationConfig{
+		channel:     cm.settings.invalidationChannel,
+		transport:   transport,
+		unsubscribe: unsubscribe,
+	}
+
+	return nil
+}
+
+// evictLocal removes key from the in-memory backend only, leaving any shared
+// backend (e.g. Redis) untouched: those are the coherence source of truth,
+// this just drops a now-stale local copy.
+func (cm *CacheManager[I, O]) evictLocal(ctx context.Context, key string) {
+	for _, backend := range cm.settings.backends {
+		if backend.Name() == "memory" {
+			_ = backend.Delete(ctx, key)
+		}
+	}
+}
+
+// publishInvalidation announces key as invalidated, if an invalidation
+// channel is configured. Failures are recorded but don't fail the caller,
+// since the cache write/delete they accompany already succeeded.
+func (cm *CacheManager[I, O]) publishInvalidation(ctx context.Context, key string) {
+	if cm.invalidation == nil {
+		return
+	}
+
+	if err := cm.invalidation.transport.Publish(ctx, cm.invalidation.channel, encodeInvalidationMessage(key)); err != nil {
+		cm.instr.recordError(ctx, "invalidate_publish")
+		cm.logDebug("publishing invalidation failed", "method", cm.name, "error", err)
+	}
+}
+
+// Invalidate evicts input's entry from every backend and notifies other
+// replicas sharing the invalidation channel to do the same to their local
+// in-memory copy.
+func (cm *CacheManager[I, O]) Invalidate(ctx context.Context, input I) error {
+	key, err := cm.key(input)
+	if err != nil {
+		return err
+	}
+
+	if err := cm.Delete(ctx, input); err != nil {
+		return err
+	}
+
+	cm.publishInvalidation(ctx, key)
+
+	return nil
+}
+
+// logDebug logs at debug level when a logger is configured, a no-op otherwise.
+func (cm *CacheManager[I, O]) logDebug(msg string, args ...any) {
+	if cm.settings.logger != nil {
+		cm.settings.logger.Debug(msg, args...)
+	}
+}
+
+// Get returns the cached output for input, populating the cache via the
+// manager's update function on a miss.
+func (cm *CacheManager[I, O]) Get(ctx context.Context, input I) (O, error) {
+	key, err := cm.key(input)
+	if err != nil {
+		var zero O
+		return zero, err
+	}
+
+	ctx, span := cm.startSpan(ctx, "get", key)
+	defer func() { endSpan(span, err) }()
+
+	for i, backend := range cm.settings.backends {
+		var raw []byte
+
+		var ok bool
+
+		raw, ok, err = backend.Get(ctx, key)
+		if err != nil {
+			cm.instr.recordError(ctx, "get")
+			err = fmt.Errorf("reading %s from cache: %w", cm.name, err)
+
+			var zero O
+
+			return zero, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		cm.instr.recordHit(ctx, backend.Name())
+		cm.instr.recordPayloadSize(ctx, len(raw))
+		cm.logDebug("cache hit", "method", cm.name, "layer", backend.Name())
+
+		// Backfill every faster tier we just skipped past, the same way
+		// tieredBackend.Get does, so a hit on a slower backend (e.g. Redis
+		// after a process restart) repopulates the in-memory tier instead of
+		// hitting it on every call for the rest of the process's life.
+		for _, earlier := range cm.settings.backends[:i] {
+			_ = earlier.Set(ctx, key, raw, 0)
+		}
+
+		if bytes.Equal(raw, negativeSentinel) {
+			err = ErrNotFound
+
+			var zero O
+
+			return zero, err
+		}
+
+		output := cm.zeroOutput()
+		if unmarshalErr := cm.settings.codec.Unmarshal(raw, output); unmarshalErr != nil {
+			err = fmt.Errorf("decoding cached %s: %w", cm.name, unmarshalErr)
+
+			var zero O
+
+			return zero, err
+		}
+
+		return output, nil
+	}
+
+	cm.instr.recordMiss(ctx)
+	cm.logDebug("cache miss", "method", cm.name)
+
+	var output O
+
+	output, err = cm.Refresh(ctx, input)
+
+	return output, err
+}
+
+// Refresh always calls the manager's update function, storing (and
+// returning) its result regardless of what was previously cached. Concurrent
+// Refresh/Get calls for the same input are coalesced into a single call to
+// the update function when WithSingleflight is set.
+func (cm *CacheManager[I, O]) Refresh(ctx context.Context, input I) (O, error) {
+	key, err := cm.key(input)
+	if err != nil {
+		var zero O
+		return zero, err
+	}
+
+	ctx, span := cm.startSpan(ctx, "refresh", key)
+	defer func() { endSpan(span, err) }()
+
+	var output O
+
+	output, err = cm.refreshOne(ctx, key, input)
+	if err != nil {
+		cm.instr.recordError(ctx, "refresh")
+
+		var zero O
+
+		return zero, err
+	}
+
+	if setErr := cm.set(ctx, key, output); setErr != nil {
+		err = setErr
+
+		var zero O
+
+		return zero, err
+	}
+
+	cm.publishInvalidation(ctx, key)
+
+	return output, nil
+}
+
+// refreshOne calls the update function for a single already-keyed input,
+// coalescing concurrent callers via singleflight when configured and
+// negative-caching an ErrNotFound result, but without writing a positive
+// result to the backends: callers decide whether to do that individually
+// (Refresh) or as part of a larger batched write (GetMany).
+func (cm *CacheManager[I, O]) refreshOne(ctx context.Context, key string, input I) (O, error) {
+	call := func() (any, error) { return cm.updateFn(ctx, input) }
+
+	var (
+		result any
+		err    error
+	)
+
+	start := time.Now()
+
+	if cm.settings.singleflight {
+		result, err, _ = cm.flight.Do(key, call)
+	} else {
+		result, err = call()
+	}
+
+	cm.instr.recordRefreshLatency(ctx, time.Since(start))
+
+	if err != nil {
+		if errors.Is(err, ErrNotFound) && cm.settings.negativeTTL > 0 {
+			if setErr := cm.setRaw(ctx, key, negativeSentinel, cm.settings.negativeTTL); setErr != nil {
+				var zero O
+				return zero, setErr
+			}
+		}
+
+		var zero O
+
+		return zero, fmt.Errorf("refreshing %s: %w", cm.name, err)
+	}
+
+	return result.(O), nil
+}
+
+// Delete evicts input's entry from every configured backend.
+func (cm *CacheManager[I, O]) Delete(ctx context.Context, input I) error {
+	key, err := cm.key(input)
+	if err != nil {
+		return err
+	}
+
+	ctx, span := cm.startSpan(ctx, "delete", key)
+	defer func() { endSpan(span, err) }()
+
+	for _, backend := range cm.settings.backends {
+		if delErr := backend.Delete(ctx, key); delErr != nil {
+			cm.instr.recordError(ctx, "delete")
+			err = fmt.Errorf("deleting %s from cache: %w", cm.name, delErr)
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Has reports whether input is cached in any backend.
+func (cm *CacheManager[I, O]) Has(ctx context.Context, input I) (bool, error) {
+	key, err := cm.key(input)
+	if err != nil {
+		return false, err
+	}
+
+	for _, backend := range cm.settings.backends {
+		ok, err := backend.Has(ctx, key)
+		if err != nil {
+			return false, fmt.Errorf("checking %s in cache: %w", cm.name, err)
+		}
+
+		if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (cm *CacheManager[I, O]) set(ctx context.Context, key string, output O) error {
+	raw, err := cm.settings.codec.Marshal(output)
+	if err != nil {
+		return fmt.Errorf("encoding %s for cache: %w", cm.name, err)
+	}
+
+	cm.instr.recordPayloadSize(ctx, len(raw))
+
+	return cm.setRaw(ctx, key, raw, cm.jitteredTTL())
+}
+
+func (cm *CacheManager[I, O]) setRaw(ctx context.Context, key string, raw []byte, ttl time.Duration) error {
+	for _, backend := range cm.settings.backends {
+		if err := backend.Set(ctx, key, raw, ttl); err != nil {
+			cm.instr.recordError(ctx, "set")
+			return fmt.Errorf("writing %s to cache: %w", cm.name, err)
+		}
+	}
+
+	return nil
+}
+
+// jitteredTTL returns the configured TTL randomized by up to the configured
+// jitter, so entries written around the same time don't all expire together.
+// A zero TTL (entries never expire) is returned unchanged.
+func (cm *CacheManager[I, O]) jitteredTTL() time.Duration {
+	if cm.settings.jitter <= 0 || cm.settings.ttl <= 0 {
+		return cm.settings.ttl
+	}
+
+	return cm.settings.ttl + time.Duration(rand.Int63n(int64(cm.settings.jitter)))
+}
+
+// namespace prefixes this manager's keys, so that distinct methods sharing a
+// backend never collide and, when configured, so keys can be filtered by
+// Prometheus prefix for manual inspection.
+func (cm *CacheManager[I, O]) namespace() string {
+	if cm.settings.prometheusPrefix != "" {
+		return cm.settings.prometheusPrefix + ":" + cm.name
+	}
+
+	return cm.name
+}
+
+// key derives the cache key for input. When input is a proto.Message, it
+// uses WithKeyFunc if configured, falling back to the keyer package's
+// canonical field-by-field hash; otherwise it hashes the codec-encoded bytes,
+// which is the only option available without a proto reflection descriptor.
+func (cm *CacheManager[I, O]) key(input I) (string, error) {
+	msg, isProto := any(input).(proto.Message)
+
+	if cm.settings.keyFunc != nil {
+		if !isProto {
+			return "", fmt.Errorf("gocachemanager: %s: WithKeyFunc requires a proto.Message input, got %T", cm.name, input)
+		}
+
+		return fmt.Sprintf("%s:%s", cm.namespace(), cm.settings.keyFunc(msg)), nil
+	}
+
+	if isProto {
+		return keyer.Key(cm.namespace(), msg), nil
+	}
+
+	raw, err := cm.settings.codec.Marshal(input)
+	if err != nil {
+		return "", fmt.Errorf("encoding %s input for cache key: %w", cm.name, err)
+	}
+
+	sum := sha256.Sum256(raw)
+
+	return fmt.Sprintf("%s:%s", cm.namespace(), hex.EncodeToString(sum[:])), nil
+}
+
+// KeyExcludingFields derives a key for input the way the default keyer-based
+// derivation does, but with the given proto field numbers left out of the
+// canonical hash, so every input that differs only in those fields (e.g. a
+// request ID) yields the same key. Get/Refresh/Delete always hash every
+// populated field, so the result equals the key they use for input only when
+// none of the excluded fields is set on input; the manager has no method
+// that deletes an entry by key.
+//
+// It only works for managers using the default keyer-based key derivation:
+// it errors if WithKeyFunc is configured, since a caller-supplied keyFunc has
+// no notion of excludable fields, and silently falling back to the keyer
+// hash there would compute a key that Get/Refresh/Delete never use. It also
+// errors if input isn't a proto.Message.
+func (cm *CacheManager[I, O]) KeyExcludingFields(input I, exclude ...protoreflect.FieldNumber) (string, error) {
+	if cm.settings.keyFunc != nil {
+		return "", fmt.Errorf(
+			"gocachemanager: %s: KeyExcludingFields is unavailable with WithKeyFunc configured",
+			cm.name,
+		)
+	}
+
+	msg, ok := any(input).(proto.Message)
+	if !ok {
+		return "", fmt.Errorf("gocachemanager: %s: KeyExcludingFields requires a proto.Message input, got %T", cm.name, input)
+	}
+
+	return keyer.Key(cm.namespace(), msg, exclude...), nil
+}
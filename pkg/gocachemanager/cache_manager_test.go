@@ -0,0 +1,64 @@
+package gocachemanager
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestGet_BackfillsEarlierBackendOnHit(t *testing.T) {
+	slow := newMemoryBackend()
+
+	cm, err := NewCacheManager[*wrapperspb.StringValue, *wrapperspb.StringValue](
+		"backfill",
+		func() *wrapperspb.StringValue { return &wrapperspb.StringValue{} },
+		func(_ context.Context, _ *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+			t.Fatal("updateFn should not be called; the slow backend already has the value")
+			return nil, nil
+		},
+		WithBackend(slow),
+	)
+	if err != nil {
+		t.Fatalf("NewCacheManager: %v", err)
+	}
+
+	input := &wrapperspb.StringValue{Value: "x"}
+
+	key, err := cm.key(input)
+	if err != nil {
+		t.Fatalf("key: %v", err)
+	}
+
+	raw, err := cm.settings.codec.Marshal(&wrapperspb.StringValue{Value: "from-slow-tier"})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	// Simulate a value that only exists in the slow tier, e.g. written by
+	// another replica or left over from before this process's in-memory
+	// tier was populated.
+	if err := slow.Set(context.Background(), key, raw, 0); err != nil {
+		t.Fatalf("slow.Set: %v", err)
+	}
+
+	output, err := cm.Get(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if output.GetValue() != "from-slow-tier" {
+		t.Errorf("output = %q, want from-slow-tier", output.GetValue())
+	}
+
+	memory := cm.settings.backends[0]
+
+	ok, err := memory.Has(context.Background(), key)
+	if err != nil {
+		t.Fatalf("memory.Has: %v", err)
+	}
+
+	if !ok {
+		t.Fatalf("Get did not backfill the in-memory tier on a hit from the slow tier")
+	}
+}
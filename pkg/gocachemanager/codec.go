@@ -0,0 +1,95 @@
+package gocachemanager
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec controls how a CacheManager turns inputs/outputs into bytes for the
+// backends and back. The default is chosen per-manager based on whether O
+// implements proto.Message (see defaultCodec); set WithCodec to use JSON,
+// msgpack or gob instead, e.g. for non-proto payloads.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// defaultCodec picks ProtoCodec for proto.Message payloads (the common case,
+// since inputs/outputs are generated proto types) and JSONCodec otherwise.
+func defaultCodec(probe any) Codec {
+	if _, ok := probe.(proto.Message); ok {
+		return ProtoCodec{}
+	}
+
+	return JSONCodec{}
+}
+
+// ProtoCodec marshals using the protobuf wire format. v must implement
+// proto.Message.
+type ProtoCodec struct{}
+
+func (ProtoCodec) Marshal(v any) ([]byte, error) {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("gocachemanager: ProtoCodec requires a proto.Message, got %T", v)
+	}
+
+	return proto.Marshal(m)
+}
+
+func (ProtoCodec) Unmarshal(data []byte, v any) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("gocachemanager: ProtoCodec requires a proto.Message, got %T", v)
+	}
+
+	return proto.Unmarshal(data, m)
+}
+
+// JSONCodec marshals using encoding/json. It works with any payload,
+// including non-proto structs.
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (JSONCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// MsgpackCodec marshals using msgpack, which is typically both smaller and
+// faster to encode/decode than JSON for numeric-heavy payloads.
+type MsgpackCodec struct{}
+
+func (MsgpackCodec) Marshal(v any) ([]byte, error) {
+	return msgpack.Marshal(v)
+}
+
+func (MsgpackCodec) Unmarshal(data []byte, v any) error {
+	return msgpack.Unmarshal(data, v)
+}
+
+// GobCodec marshals using encoding/gob. Unlike the other codecs it requires
+// the concrete type to be registered (via gob.Register) if it's stored
+// behind an interface value.
+type GobCodec struct{}
+
+func (GobCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (GobCodec) Unmarshal(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
@@ -0,0 +1,16 @@
+package gocachemanager
+
+import "errors"
+
+// ErrNotFound is the sentinel error an update function should wrap (via
+// fmt.Errorf("...: %w", gocachemanager.ErrNotFound) or similar) to signal
+// that the input has no result. When WithNegativeTTL is configured, that
+// result is cached as such for the configured duration instead of being
+// re-fetched on every Get.
+var ErrNotFound = errors.New("gocachemanager: not found")
+
+// negativeSentinel is the value stored in backends to represent a cached
+// ErrNotFound result. It can never collide with a real proto.Marshal output:
+// a protobuf encoding never starts with a 0 byte, since field number 0 is
+// invalid, so this single NUL byte is reserved for the sentinel.
+var negativeSentinel = []byte{0}
@@ -0,0 +1,137 @@
+package gocachemanager
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// replicaID identifies this process among the replicas sharing an
+// invalidation channel. publishInvalidation tags every message with it so
+// subscribeInvalidation can recognize and skip messages this same process
+// published, instead of evicting the in-memory entry it just wrote (Publish
+// fires right after a fresh value is stored, and Subscribe delivers every
+// publisher's messages "including this one", so without this the in-memory
+// tier would be evicted on every fill before it ever serves a hit).
+var replicaID = newReplicaID()
+
+func newReplicaID() string {
+	var buf [16]byte
+
+	if _, err := cryptorand.Read(buf[:]); err != nil {
+		return fmt.Sprintf("pid-%d-%d", os.Getpid(), time.Now().UnixNano())
+	}
+
+	return hex.EncodeToString(buf[:])
+}
+
+// invalidationOriginSep separates the origin replica id from the key in a
+// published invalidation message. It's a control character that never
+// appears in the origin replica id, so splitting a message at its first
+// occurrence recovers the key intact.
+const invalidationOriginSep = "\x00"
+
+// encodeInvalidationMessage tags key with this replica's origin id.
+func encodeInvalidationMessage(key string) string {
+	return replicaID + invalidationOriginSep + key
+}
+
+// decodeInvalidationMessage splits a received message back into its origin
+// replica id and key.
+func decodeInvalidationMessage(msg string) (origin, key string) {
+	idx := strings.IndexByte(msg, invalidationOriginSep[0])
+	if idx < 0 {
+		return "", msg
+	}
+
+	return msg[:idx], msg[idx+1:]
+}
+
+// InvalidationTransport lets a fleet of CacheManagers sharing a backend stay
+// coherent: whoever updates an entry publishes its key, and every other
+// replica evicts its local in-memory copy without waiting for TTL expiry.
+// The default implementation is backed by Redis pub/sub; implement this
+// interface yourself (e.g. on top of NATS) to use a different bus.
+type InvalidationTransport interface {
+	// Publish announces an invalidation on channel. CacheManager passes the
+	// invalidated key tagged with its origin replica id as an opaque string,
+	// which the transport delivers to subscribers unchanged.
+	Publish(ctx context.Context, channel, key string) error
+
+	// Subscribe calls onMessage with every string published on channel by
+	// any publisher, including this one. It returns a function that stops
+	// the subscription.
+	Subscribe(ctx context.Context, channel string, onMessage func(key string)) (unsubscribe func() error, err error)
+}
+
+// invalidationConfig bundles the transport and channel a CacheManager
+// invalidates through, once both are resolved at construction time.
+type invalidationConfig struct {
+	channel     string
+	transport   InvalidationTransport
+	unsubscribe func() error
+}
+
+// redisClienter is implemented by backends that can hand out their
+// underlying *redis.Client, so a Redis-backed invalidation transport can be
+// derived from an already-configured redis:// backend instead of requiring
+// a second connection string.
+type redisClienter interface {
+	redisClient() *redis.Client
+}
+
+// redisInvalidationTransport is the default InvalidationTransport, backed by
+// a Redis pub/sub channel.
+type redisInvalidationTransport struct {
+	client *redis.Client
+}
+
+func newRedisInvalidationTransport(client *redis.Client) *redisInvalidationTransport {
+	return &redisInvalidationTransport{client: client}
+}
+
+func (t *redisInvalidationTransport) Publish(ctx context.Context, channel, key string) error {
+	return t.client.Publish(ctx, channel, key).Err()
+}
+
+func (t *redisInvalidationTransport) Subscribe(
+	ctx context.Context,
+	channel string,
+	onMessage func(key string),
+) (func() error, error) {
+	sub := t.client.Subscribe(ctx, channel)
+
+	go func() {
+		for msg := range sub.Channel() {
+			onMessage(msg.Payload)
+		}
+	}()
+
+	return sub.Close, nil
+}
+
+// resolveInvalidationTransport returns the configured transport, or derives
+// one from the first redis:// backend in the manager's backend chain.
+func resolveInvalidationTransport(settings *CacheSettings) (InvalidationTransport, error) {
+	if settings.invalidationTransport != nil {
+		return settings.invalidationTransport, nil
+	}
+
+	for _, backend := range settings.backends {
+		if rc, ok := backend.(redisClienter); ok {
+			return newRedisInvalidationTransport(rc.redisClient()), nil
+		}
+	}
+
+	return nil, errors.New(
+		"invalidation channel configured but no transport available: " +
+			"configure WithRedisConnection/WithBackendURI(\"redis://...\") or set WithInvalidationTransport explicitly",
+	)
+}
@@ -0,0 +1,118 @@
+package gocachemanager
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// fakeInvalidationTransport delivers Publish calls straight to whatever
+// onMessage Subscribe last registered, synchronously, the same way a real
+// pub/sub bus delivers a publisher's own messages back to its subscription.
+type fakeInvalidationTransport struct {
+	onMessage func(string)
+}
+
+func (f *fakeInvalidationTransport) Publish(_ context.Context, _, key string) error {
+	if f.onMessage != nil {
+		f.onMessage(key)
+	}
+
+	return nil
+}
+
+func (f *fakeInvalidationTransport) Subscribe(
+	_ context.Context,
+	_ string,
+	onMessage func(string),
+) (func() error, error) {
+	f.onMessage = onMessage
+
+	return func() error { return nil }, nil
+}
+
+func newInvalidationTestManager(t *testing.T, transport InvalidationTransport) *CacheManager[*wrapperspb.StringValue, *wrapperspb.StringValue] {
+	t.Helper()
+
+	cm, err := NewCacheManager[*wrapperspb.StringValue, *wrapperspb.StringValue](
+		"invalidation",
+		func() *wrapperspb.StringValue { return &wrapperspb.StringValue{} },
+		func(_ context.Context, input *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+			return input, nil
+		},
+		WithInvalidationChannel("ch"),
+		WithInvalidationTransport(transport),
+	)
+	if err != nil {
+		t.Fatalf("NewCacheManager: %v", err)
+	}
+
+	t.Cleanup(func() { _ = cm.Close() })
+
+	return cm
+}
+
+func TestInvalidation_SkipsSelfOriginatedMessages(t *testing.T) {
+	cm := newInvalidationTestManager(t, &fakeInvalidationTransport{})
+
+	input := &wrapperspb.StringValue{Value: "x"}
+
+	if _, err := cm.Refresh(context.Background(), input); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+
+	// Refresh published its own key, and the fake transport delivered that
+	// message straight back to the subscriber that published it. Without
+	// the origin tag, that delivery would evict the in-memory entry Refresh
+	// just wrote.
+	ok, err := cm.Has(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Has: %v", err)
+	}
+
+	if !ok {
+		t.Fatalf("entry was evicted by its own publish; self-origin filtering isn't working")
+	}
+}
+
+func TestInvalidation_EvictsForeignOriginMessages(t *testing.T) {
+	transport := &fakeInvalidationTransport{}
+	cm := newInvalidationTestManager(t, transport)
+
+	input := &wrapperspb.StringValue{Value: "x"}
+
+	if _, err := cm.Refresh(context.Background(), input); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+
+	key, err := cm.key(input)
+	if err != nil {
+		t.Fatalf("key: %v", err)
+	}
+
+	// Simulate a message published by another replica for the same key.
+	transport.onMessage("another-replica" + invalidationOriginSep + key)
+
+	ok, err := cm.Has(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Has: %v", err)
+	}
+
+	if ok {
+		t.Fatalf("entry should have been evicted by a foreign-origin invalidation message")
+	}
+}
+
+func TestEncodeDecodeInvalidationMessage_RoundTrips(t *testing.T) {
+	msg := encodeInvalidationMessage("method:abc123")
+
+	origin, key := decodeInvalidationMessage(msg)
+	if origin != replicaID {
+		t.Errorf("origin = %q, want %q", origin, replicaID)
+	}
+
+	if key != "method:abc123" {
+		t.Errorf("key = %q, want %q", key, "method:abc123")
+	}
+}
@@ -0,0 +1,188 @@
+package gocachemanager
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// prometheusMetrics are the counters exposed under a given prometheusPrefix.
+// Every CacheManager sharing a prefix shares the same collectors, labeled by
+// method, so construction a manager per RPC doesn't trip Prometheus's
+// duplicate-registration panic.
+type prometheusMetrics struct {
+	hits           *prometheus.CounterVec
+	misses         *prometheus.CounterVec
+	errors         *prometheus.CounterVec
+	refreshSeconds *prometheus.HistogramVec
+}
+
+var (
+	prometheusMetricsMu       sync.Mutex
+	prometheusMetricsByPrefix = map[string]*prometheusMetrics{}
+)
+
+func prometheusMetricsFor(prefix string) *prometheusMetrics {
+	prometheusMetricsMu.Lock()
+	defer prometheusMetricsMu.Unlock()
+
+	if m, ok := prometheusMetricsByPrefix[prefix]; ok {
+		return m
+	}
+
+	metricPrefix := prefix
+	if metricPrefix != "" {
+		metricPrefix += "_"
+	}
+
+	m := &prometheusMetrics{
+		hits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metricPrefix + "cache_hits_total",
+			Help: "Number of cache hits, by method and backend layer.",
+		}, []string{"method", "layer"}),
+		misses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metricPrefix + "cache_misses_total",
+			Help: "Number of cache misses, by method.",
+		}, []string{"method"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metricPrefix + "cache_errors_total",
+			Help: "Number of cache operation errors, by method and operation.",
+		}, []string{"method", "operation"}),
+		refreshSeconds: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: metricPrefix + "cache_refresh_duration_seconds",
+			Help: "Latency of cache refresh calls, by method.",
+		}, []string{"method"}),
+	}
+
+	prometheus.MustRegister(m.hits, m.misses, m.errors, m.refreshSeconds)
+	prometheusMetricsByPrefix[prefix] = m
+
+	return m
+}
+
+// otelMetrics are the OTel instruments emitted in parallel with the
+// Prometheus counters above, for callers on the OTel metrics stack.
+type otelMetrics struct {
+	hits           metric.Int64Counter
+	misses         metric.Int64Counter
+	errors         metric.Int64Counter
+	payloadBytes   metric.Int64Histogram
+	refreshSeconds metric.Float64Histogram
+}
+
+func newOTelMetrics(meterProvider metric.MeterProvider) (*otelMetrics, error) {
+	meter := meterProvider.Meter("github.com/NSXBet/go-cache-manager")
+
+	hits, err := meter.Int64Counter("gocachemanager.cache.hits")
+	if err != nil {
+		return nil, err
+	}
+
+	misses, err := meter.Int64Counter("gocachemanager.cache.misses")
+	if err != nil {
+		return nil, err
+	}
+
+	errs, err := meter.Int64Counter("gocachemanager.cache.errors")
+	if err != nil {
+		return nil, err
+	}
+
+	payloadBytes, err := meter.Int64Histogram("gocachemanager.cache.payload_bytes")
+	if err != nil {
+		return nil, err
+	}
+
+	refreshSeconds, err := meter.Float64Histogram("gocachemanager.cache.refresh_duration_seconds")
+	if err != nil {
+		return nil, err
+	}
+
+	return &otelMetrics{
+		hits:           hits,
+		misses:         misses,
+		errors:         errs,
+		payloadBytes:   payloadBytes,
+		refreshSeconds: refreshSeconds,
+	}, nil
+}
+
+// instrumentation bundles the Prometheus and OTel metrics machinery for a
+// single CacheManager. Either metrics field may be nil when the corresponding
+// CacheOption wasn't set, in which case that signal is simply skipped.
+type instrumentation struct {
+	method string
+
+	prometheus *prometheusMetrics
+	otel       *otelMetrics
+}
+
+func newInstrumentation(method string, settings *CacheSettings) (*instrumentation, error) {
+	instr := &instrumentation{method: method}
+
+	if settings.prometheusPrefix != "" {
+		instr.prometheus = prometheusMetricsFor(settings.prometheusPrefix)
+	}
+
+	if settings.meterProvider != nil {
+		otelMetrics, err := newOTelMetrics(settings.meterProvider)
+		if err != nil {
+			return nil, err
+		}
+
+		instr.otel = otelMetrics
+	}
+
+	return instr, nil
+}
+
+func (i *instrumentation) recordHit(ctx context.Context, layer string) {
+	if i.prometheus != nil {
+		i.prometheus.hits.WithLabelValues(i.method, layer).Inc()
+	}
+
+	if i.otel != nil {
+		i.otel.hits.Add(ctx, 1, metric.WithAttributes(cacheLayerAttr(layer)))
+	}
+}
+
+func (i *instrumentation) recordMiss(ctx context.Context) {
+	if i.prometheus != nil {
+		i.prometheus.misses.WithLabelValues(i.method).Inc()
+	}
+
+	if i.otel != nil {
+		i.otel.misses.Add(ctx, 1)
+	}
+}
+
+func (i *instrumentation) recordError(ctx context.Context, operation string) {
+	if i.prometheus != nil {
+		i.prometheus.errors.WithLabelValues(i.method, operation).Inc()
+	}
+
+	if i.otel != nil {
+		i.otel.errors.Add(ctx, 1, metric.WithAttributes(cacheOperationAttr(operation)))
+	}
+}
+
+func (i *instrumentation) recordPayloadSize(ctx context.Context, size int) {
+	if i.otel != nil {
+		i.otel.payloadBytes.Record(ctx, int64(size))
+	}
+}
+
+func (i *instrumentation) recordRefreshLatency(ctx context.Context, d time.Duration) {
+	seconds := d.Seconds()
+
+	if i.prometheus != nil {
+		i.prometheus.refreshSeconds.WithLabelValues(i.method).Observe(seconds)
+	}
+
+	if i.otel != nil {
+		i.otel.refreshSeconds.Record(ctx, seconds)
+	}
+}
@@ -1,23 +1,81 @@
 package gocachemanager
 
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+)
+
 // CacheSettings contains the configuration for building a cache manager.
 type CacheSettings struct {
-	// RedisConnection is the connection string for the Redis server.
-	// Defaults to empty string, meaning no Redis connection. So no cache will be used in Redis.
-	redisConnection string
+	// backends is the ordered list of stores a CacheManager reads through and
+	// writes to, fastest first. Defaults to a single in-memory backend.
+	backends []Backend
 
 	// skipInMemoryCache is a flag to skip the in-memory cache and utilize redis only.
 	// Defaults to false, meaning in-memory cache is used.
 	skipInMemoryCache bool
 
+	// ttl is how long entries live in the backends before they expire. Zero
+	// means entries never expire.
+	ttl time.Duration
+
+	// jitter randomizes each entry's ttl by up to this amount, so that
+	// entries written around the same time don't all expire together.
+	jitter time.Duration
+
+	// singleflight coalesces concurrent calls to the update function for the
+	// same input into a single call.
+	singleflight bool
+
+	// negativeTTL is how long a gocachemanager.ErrNotFound result is cached
+	// for. Zero disables negative caching.
+	negativeTTL time.Duration
+
 	// prometheusPrefix will be used whenever sending cache metrics to Prometheus.
 	prometheusPrefix string
+
+	// tracerProvider, if set, traces every Get/Refresh/Delete call. Nil
+	// disables tracing.
+	tracerProvider trace.TracerProvider
+
+	// meterProvider, if set, emits OTel metrics alongside the Prometheus
+	// counters above. Nil disables OTel metrics.
+	meterProvider metric.MeterProvider
+
+	// logger, if set, receives structured log records for cache operations.
+	// Nil disables logging.
+	logger *slog.Logger
+
+	// invalidationChannel, if set, makes the manager publish and subscribe to
+	// this pub/sub channel so replicas evict their local in-memory copy of an
+	// entry as soon as any of them invalidates it, instead of waiting for TTL.
+	invalidationChannel string
+
+	// invalidationTransport is the pub/sub implementation used for
+	// invalidation. Defaults to Redis, derived from the configured redis://
+	// backend, when left nil.
+	invalidationTransport InvalidationTransport
+
+	// codec marshals outputs (and non-proto inputs, which are hashed for the
+	// cache key) into bytes. Defaults to ProtoCodec for proto.Message payloads and JSONCodec
+	// otherwise, decided once NewCacheManager has a zero output to probe.
+	codec Codec
+
+	// keyFunc, if set, replaces the keyer package's canonical field-by-field
+	// hash with a caller-supplied derivation, e.g. to exclude fields from the
+	// key without recompiling the proto with a differently-shaped message.
+	// Only usable when I implements proto.Message.
+	keyFunc func(proto.Message) string
 }
 
 // DefaultCacheSettings returns the default cache settings.
 func DefaultCacheSettings() *CacheSettings {
 	return &CacheSettings{
-		redisConnection:   "", // No Redis connection by default
 		skipInMemoryCache: false,
 	}
 }
@@ -25,13 +83,34 @@ func DefaultCacheSettings() *CacheSettings {
 // CacheOption is an interface for applying cache settings.
 type CacheOption func(*CacheSettings)
 
-// WithRedisConnection is a cache option for setting the Redis connection string.
-func WithRedisConnection(redisConnection string) CacheOption {
+// WithBackend is a cache option that appends a Backend to the manager's
+// backend chain, in the order the options are applied.
+func WithBackend(backend Backend) CacheOption {
 	return func(settings *CacheSettings) {
-		settings.redisConnection = redisConnection
+		settings.backends = append(settings.backends, backend)
 	}
 }
 
+// WithBackendURI is a cache option that appends the Backend built by
+// NewFromURI to the manager's backend chain. It panics if the URI is
+// malformed or names an unregistered scheme, since backend configuration
+// errors should be caught at wiring time, not at the first cache miss.
+func WithBackendURI(uri string) CacheOption {
+	backend, err := NewFromURI(uri)
+	if err != nil {
+		panic(err)
+	}
+
+	return WithBackend(backend)
+}
+
+// WithRedisConnection is a cache option for adding a Redis backend using the
+// given connection string, e.g. "host:port?db=0&password=...". It is a thin
+// wrapper around WithBackendURI("redis://" + redisConnection).
+func WithRedisConnection(redisConnection string) CacheOption {
+	return WithBackendURI("redis://" + redisConnection)
+}
+
 // WithSkipInMemoryCache is a cache option for skipping the in-memory cache.
 func WithSkipInMemoryCache() CacheOption {
 	return func(settings *CacheSettings) {
@@ -44,4 +123,123 @@ func WithPrometheusPrefix(prometheusPrefix string) CacheOption {
 	return func(settings *CacheSettings) {
 		settings.prometheusPrefix = prometheusPrefix
 	}
-}
\ No newline at end of file
+}
+
+// MustParseDuration parses a duration string, panicking if it is invalid. It
+// exists so generated code can turn a protobuf method option's string
+// duration into a time.Duration without threading a parse error through the
+// generated constructor.
+func MustParseDuration(s string) time.Duration {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		panic(fmt.Errorf("gocachemanager: invalid duration %q: %w", s, err))
+	}
+
+	return d
+}
+
+// WithTTL is a cache option for setting how long entries live in the
+// backends before they expire.
+func WithTTL(ttl time.Duration) CacheOption {
+	return func(settings *CacheSettings) {
+		settings.ttl = ttl
+	}
+}
+
+// WithJitter is a cache option that randomizes each entry's TTL by up to the
+// given amount, preventing synchronized expiration stampedes across
+// replicas that wrote the same key around the same time.
+func WithJitter(jitter time.Duration) CacheOption {
+	return func(settings *CacheSettings) {
+		settings.jitter = jitter
+	}
+}
+
+// WithSingleflight is a cache option that coalesces concurrent Get calls for
+// the same input into a single call to the update function.
+func WithSingleflight() CacheOption {
+	return func(settings *CacheSettings) {
+		settings.singleflight = true
+	}
+}
+
+// WithNegativeTTL is a cache option that caches a gocachemanager.ErrNotFound
+// result returned by the update function for the given duration, so repeated
+// lookups of a missing entry don't all hit the upstream.
+func WithNegativeTTL(negativeTTL time.Duration) CacheOption {
+	return func(settings *CacheSettings) {
+		settings.negativeTTL = negativeTTL
+	}
+}
+
+// WithTracerProvider is a cache option for tracing every Get, Refresh and
+// Delete call; a Refresh span also covers its write to the backends. Spans
+// carry the method name and the cache key (as cache.key_hash) and mark
+// errors; they do not record the cache layer, hit/miss, payload size or
+// refresh latency. GetMany and SetMany are not traced.
+func WithTracerProvider(tracerProvider trace.TracerProvider) CacheOption {
+	return func(settings *CacheSettings) {
+		settings.tracerProvider = tracerProvider
+	}
+}
+
+// WithMeterProvider is a cache option for emitting OTel metrics alongside
+// the existing Prometheus counters, for users who are on the OTel metrics
+// stack instead of (or in addition to) Prometheus.
+func WithMeterProvider(meterProvider metric.MeterProvider) CacheOption {
+	return func(settings *CacheSettings) {
+		settings.meterProvider = meterProvider
+	}
+}
+
+// WithLogger is a cache option for structured logging of cache operations.
+func WithLogger(logger *slog.Logger) CacheOption {
+	return func(settings *CacheSettings) {
+		settings.logger = logger
+	}
+}
+
+// WithInvalidationChannel is a cache option that makes the manager subscribe
+// to, and publish on, a pub/sub channel: whenever any replica invalidates an
+// entry (via Invalidate or Refresh), every other replica evicts its local
+// in-memory copy without waiting for TTL expiry. Requires a Redis backend to
+// be configured, unless WithInvalidationTransport is also set.
+func WithInvalidationChannel(channel string) CacheOption {
+	return func(settings *CacheSettings) {
+		settings.invalidationChannel = channel
+	}
+}
+
+// WithInvalidationTransport is a cache option for using a pub/sub
+// implementation other than the default Redis one (e.g. NATS) for
+// WithInvalidationChannel.
+func WithInvalidationTransport(transport InvalidationTransport) CacheOption {
+	return func(settings *CacheSettings) {
+		settings.invalidationTransport = transport
+	}
+}
+
+// WithCodec is a cache option for choosing how outputs are marshaled (and
+// non-proto inputs encoded to be hashed for the cache key; proto inputs are
+// keyed by the keyer package) instead of the default, which is
+// ProtoCodec for proto.Message payloads and JSONCodec otherwise. Use this to
+// opt into MsgpackCodec or GobCodec, or to cache payloads that aren't proto
+// messages at all.
+func WithCodec(codec Codec) CacheOption {
+	return func(settings *CacheSettings) {
+		settings.codec = codec
+	}
+}
+
+// WithKeyFunc is a cache option for deriving the cache key with a
+// caller-supplied function instead of the keyer package's default canonical
+// hash. Typical use is excluding noisy fields (e.g. a request ID) from the
+// key. The generated <Method>KeyExcludingFields helper does not change the
+// key Get/Refresh/Delete use, and it returns an error once this option is
+// set. I must implement proto.Message: with any other input type, every key
+// derivation fails with an error.
+func WithKeyFunc(keyFunc func(proto.Message) string) CacheOption {
+	return func(settings *CacheSettings) {
+		settings.keyFunc = keyFunc
+	}
+}
@@ -0,0 +1,113 @@
+package gocachemanager
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestWithSingleflight_CoalescesConcurrentCalls(t *testing.T) {
+	var calls int32
+
+	block := make(chan struct{})
+
+	cm, err := NewCacheManager[*wrapperspb.StringValue, *wrapperspb.StringValue](
+		"singleflight",
+		func() *wrapperspb.StringValue { return &wrapperspb.StringValue{} },
+		func(_ context.Context, input *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+			atomic.AddInt32(&calls, 1)
+			<-block
+
+			return &wrapperspb.StringValue{Value: input.GetValue()}, nil
+		},
+		WithSingleflight(),
+	)
+	if err != nil {
+		t.Fatalf("NewCacheManager: %v", err)
+	}
+
+	const concurrency = 10
+
+	done := make(chan struct{}, concurrency)
+
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+
+			if _, err := cm.Refresh(context.Background(), &wrapperspb.StringValue{Value: "x"}); err != nil {
+				t.Errorf("Refresh: %v", err)
+			}
+		}()
+	}
+
+	// Give every goroutine a chance to join the in-flight call before
+	// unblocking it.
+	time.Sleep(50 * time.Millisecond)
+	close(block)
+
+	for i := 0; i < concurrency; i++ {
+		<-done
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("updateFn called %d times, want 1 (coalesced by singleflight)", got)
+	}
+}
+
+func TestWithJitter_BoundsTTL(t *testing.T) {
+	cm, err := NewCacheManager[*wrapperspb.StringValue, *wrapperspb.StringValue](
+		"jitter",
+		func() *wrapperspb.StringValue { return &wrapperspb.StringValue{} },
+		func(_ context.Context, input *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+			return input, nil
+		},
+		WithTTL(10*time.Second),
+		WithJitter(5*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("NewCacheManager: %v", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		ttl := cm.jitteredTTL()
+		if ttl < 10*time.Second || ttl >= 15*time.Second {
+			t.Fatalf("jitteredTTL() = %v, want within [10s, 15s)", ttl)
+		}
+	}
+}
+
+func TestWithNegativeTTL_CachesNotFound(t *testing.T) {
+	var calls int32
+
+	cm, err := NewCacheManager[*wrapperspb.StringValue, *wrapperspb.StringValue](
+		"negative-ttl",
+		func() *wrapperspb.StringValue { return &wrapperspb.StringValue{} },
+		func(_ context.Context, _ *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+			atomic.AddInt32(&calls, 1)
+
+			return nil, ErrNotFound
+		},
+		WithNegativeTTL(time.Minute),
+	)
+	if err != nil {
+		t.Fatalf("NewCacheManager: %v", err)
+	}
+
+	input := &wrapperspb.StringValue{Value: "x"}
+
+	if _, err := cm.Get(context.Background(), input); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("first Get err = %v, want ErrNotFound", err)
+	}
+
+	if _, err := cm.Get(context.Background(), input); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("second Get err = %v, want ErrNotFound", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("updateFn called %d times, want 1 (second Get should hit the negative cache)", got)
+	}
+}
@@ -0,0 +1,45 @@
+package gocachemanager
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func cacheLayerAttr(layer string) attribute.KeyValue {
+	return attribute.String("cache.layer", layer)
+}
+
+func cacheOperationAttr(operation string) attribute.KeyValue {
+	return attribute.String("cache.operation", operation)
+}
+
+// startSpan starts a span for op ("get", "refresh", "delete") when a
+// TracerProvider is configured, returning a no-op span otherwise so callers
+// can unconditionally call span.End()/RecordError().
+func (cm *CacheManager[I, O]) startSpan(
+	ctx context.Context,
+	op, key string,
+) (context.Context, trace.Span) {
+	if cm.settings.tracerProvider == nil {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+
+	tracer := cm.settings.tracerProvider.Tracer("github.com/NSXBet/go-cache-manager")
+
+	return tracer.Start(ctx, "gocachemanager."+cm.name+"."+op, trace.WithAttributes(
+		attribute.String("cache.method", cm.name),
+		attribute.String("cache.key_hash", key),
+	))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,39 @@
+package gocachemanager
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// NewFromURI builds a Backend from a URI whose scheme selects the
+// implementation, e.g.:
+//
+//	memory://
+//	redis://host:port?db=0&password=secret
+//	memcache://host1,host2
+//	tiered://memory,redis://host:port
+//
+// Third-party backends registered via RegisterBackend are selected the same
+// way, by scheme.
+func NewFromURI(uri string) (Backend, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("parsing backend uri %q: %w", uri, err)
+	}
+
+	if parsed.Scheme == "" {
+		return nil, fmt.Errorf("backend uri %q has no scheme", uri)
+	}
+
+	factory, err := lookupBackendFactory(parsed.Scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	backend, err := factory(uri)
+	if err != nil {
+		return nil, fmt.Errorf("building %s backend: %w", parsed.Scheme, err)
+	}
+
+	return backend, nil
+}
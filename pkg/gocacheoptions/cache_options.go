@@ -0,0 +1,78 @@
+// Package gocacheoptions defines the custom protobuf MethodOptions extensions
+// declared in cache_options.proto, so that cmd/generator.go can read them off
+// a method's descriptor without depending on protoc having been run against
+// this package. Regenerate this file's descriptor by hand whenever
+// cache_options.proto changes.
+package gocacheoptions
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var file = buildFileDescriptor()
+
+// Ttl, Jitter, Singleflight and NegativeTTL are the extension types for the
+// method options declared in cache_options.proto: (gocachemanager.ttl),
+// (gocachemanager.jitter), (gocachemanager.singleflight) and
+// (gocachemanager.negative_ttl) respectively.
+var (
+	Ttl          = dynamicpb.NewExtensionType(extensionField(file, "ttl"))
+	Jitter       = dynamicpb.NewExtensionType(extensionField(file, "jitter"))
+	Singleflight = dynamicpb.NewExtensionType(extensionField(file, "singleflight"))
+	NegativeTTL  = dynamicpb.NewExtensionType(extensionField(file, "negative_ttl"))
+)
+
+func buildFileDescriptor() protoreflect.FileDescriptor {
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("gocachemanager/cache_options.proto"),
+		Package:    proto.String("gocachemanager"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/descriptor.proto"},
+		Options: &descriptorpb.FileOptions{
+			GoPackage: proto.String("github.com/NSXBet/go-cache-manager/pkg/gocacheoptions"),
+		},
+		Extension: []*descriptorpb.FieldDescriptorProto{
+			extensionFieldProto("ttl", 50001, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+			extensionFieldProto("jitter", 50002, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+			extensionFieldProto("singleflight", 50003, descriptorpb.FieldDescriptorProto_TYPE_BOOL),
+			extensionFieldProto("negative_ttl", 50004, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+		},
+	}, nil)
+	if err != nil {
+		panic(fmt.Errorf("building gocachemanager/cache_options.proto descriptor: %w", err))
+	}
+
+	return fd
+}
+
+func extensionFieldProto(
+	name string,
+	number int32,
+	kind descriptorpb.FieldDescriptorProto_Type,
+) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     kind.Enum(),
+		Extendee: proto.String(".google.protobuf.MethodOptions"),
+		JsonName: proto.String(name),
+	}
+}
+
+func extensionField(file protoreflect.FileDescriptor, name string) protoreflect.ExtensionDescriptor {
+	extensions := file.Extensions()
+	for i := 0; i < extensions.Len(); i++ {
+		if string(extensions.Get(i).Name()) == name {
+			return extensions.Get(i)
+		}
+	}
+
+	panic(fmt.Sprintf("gocacheoptions: unknown extension field %q", name))
+}
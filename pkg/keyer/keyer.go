@@ -0,0 +1,172 @@
+// Package keyer derives deterministic, collision-resistant cache keys from
+// proto messages by walking their reflection descriptor instead of hashing
+// proto.Marshal's wire bytes: the wire format does not guarantee a stable
+// field order across proto library versions (and map iteration order is
+// explicitly unspecified), so hashing it directly is fragile.
+package keyer
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/cespare/xxhash/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Hash derives a collision-resistant hash of msg's populated fields. Fields
+// are visited in field-number order (stable regardless of .proto declaration
+// order or which optional fields happen to be set), and every value is
+// length-prefixed before hashing so that, e.g., a two-field message with
+// values "ab"/"c" can never hash the same as one with values "a"/"bc".
+// Fields in exclude are skipped entirely, so callers can omit noisy fields
+// (e.g. a request ID) from the key.
+func Hash(msg proto.Message, exclude ...protoreflect.FieldNumber) uint64 {
+	excluded := make(map[protoreflect.FieldNumber]bool, len(exclude))
+	for _, number := range exclude {
+		excluded[number] = true
+	}
+
+	digest := xxhash.New()
+	hashMessage(digest, msg.ProtoReflect(), excluded)
+
+	return digest.Sum64()
+}
+
+// Key derives a namespaced cache key for msg: prefix followed by a colon and
+// the hex-encoded Hash of msg.
+func Key(prefix string, msg proto.Message, exclude ...protoreflect.FieldNumber) string {
+	return prefix + ":" + formatHash(Hash(msg, exclude...))
+}
+
+func formatHash(h uint64) string {
+	const hexDigits = "0123456789abcdef"
+
+	buf := make([]byte, 16)
+	for i := 15; i >= 0; i-- {
+		buf[i] = hexDigits[h&0xf]
+		h >>= 4
+	}
+
+	return string(buf)
+}
+
+func hashMessage(digest *xxhash.Digest, m protoreflect.Message, excluded map[protoreflect.FieldNumber]bool) {
+	fields := m.Descriptor().Fields()
+
+	populated := make(map[protoreflect.FieldNumber]protoreflect.FieldDescriptor, fields.Len())
+
+	numbers := make([]int, 0, fields.Len())
+
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if excluded[fd.Number()] || !m.Has(fd) {
+			continue
+		}
+
+		populated[fd.Number()] = fd
+		numbers = append(numbers, int(fd.Number()))
+	}
+
+	sort.Ints(numbers)
+
+	for _, number := range numbers {
+		fd := populated[protoreflect.FieldNumber(number)]
+		writeBytes(digest, fieldNumberBytes(fd.Number()))
+		hashField(digest, fd, m.Get(fd), excluded)
+	}
+}
+
+func hashField(
+	digest *xxhash.Digest,
+	fd protoreflect.FieldDescriptor,
+	v protoreflect.Value,
+	excluded map[protoreflect.FieldNumber]bool,
+) {
+	switch {
+	case fd.IsMap():
+		keys := make([]protoreflect.MapKey, 0, v.Map().Len())
+		v.Map().Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+			keys = append(keys, k)
+			return true
+		})
+
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+		for _, k := range keys {
+			writeBytes(digest, []byte(k.String()))
+			hashScalarOrMessage(digest, fd.MapValue(), v.Map().Get(k), excluded)
+		}
+	case fd.IsList():
+		list := v.List()
+		for i := 0; i < list.Len(); i++ {
+			hashScalarOrMessage(digest, fd, list.Get(i), excluded)
+		}
+	default:
+		hashScalarOrMessage(digest, fd, v, excluded)
+	}
+}
+
+func hashScalarOrMessage(
+	digest *xxhash.Digest,
+	fd protoreflect.FieldDescriptor,
+	v protoreflect.Value,
+	excluded map[protoreflect.FieldNumber]bool,
+) {
+	if fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind {
+		hashMessage(digest, v.Message(), excluded)
+		return
+	}
+
+	writeBytes(digest, scalarBytes(fd, v))
+}
+
+// scalarBytes renders a scalar field's value as a fixed, type-specific byte
+// sequence, rather than a textual representation whose formatting rules
+// could change.
+func scalarBytes(fd protoreflect.FieldDescriptor, v protoreflect.Value) []byte {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if v.Bool() {
+			return []byte{1}
+		}
+
+		return []byte{0}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return uint64Bytes(uint64(v.Int()))
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return uint64Bytes(v.Uint())
+	case protoreflect.FloatKind:
+		return uint64Bytes(uint64(math.Float32bits(float32(v.Float()))))
+	case protoreflect.DoubleKind:
+		return uint64Bytes(math.Float64bits(v.Float()))
+	case protoreflect.EnumKind:
+		return uint64Bytes(uint64(v.Enum()))
+	case protoreflect.BytesKind:
+		return v.Bytes()
+	default:
+		// StringKind and anything else protoreflect adds in the future.
+		return []byte(v.String())
+	}
+}
+
+func fieldNumberBytes(number protoreflect.FieldNumber) []byte {
+	return uint64Bytes(uint64(number))
+}
+
+func uint64Bytes(v uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+
+	return buf[:]
+}
+
+// writeBytes length-prefixes b before writing it, so two adjacent values
+// can't be confused for one concatenated value.
+func writeBytes(digest *xxhash.Digest, b []byte) {
+	_, _ = digest.Write(uint64Bytes(uint64(len(b))))
+	_, _ = digest.Write(b)
+}
@@ -0,0 +1,76 @@
+package keyer
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestKey_Deterministic(t *testing.T) {
+	msg := &timestamppb.Timestamp{Seconds: 10, Nanos: 20}
+
+	if Key("ns", msg) != Key("ns", msg) {
+		t.Fatalf("Key is not deterministic across repeated calls")
+	}
+}
+
+func TestKey_DiffersOnValue(t *testing.T) {
+	a := &timestamppb.Timestamp{Seconds: 10, Nanos: 20}
+	b := &timestamppb.Timestamp{Seconds: 10, Nanos: 21}
+
+	if Key("ns", a) == Key("ns", b) {
+		t.Fatalf("distinct messages hashed to the same key")
+	}
+}
+
+func TestKey_Prefix(t *testing.T) {
+	msg := &timestamppb.Timestamp{Seconds: 10, Nanos: 20}
+
+	want := "ns:" + formatHash(Hash(msg))
+	if got := Key("ns", msg); got != want {
+		t.Errorf("Key(%q, msg) = %q, want %q", "ns", got, want)
+	}
+}
+
+func TestHash_ExcludeField(t *testing.T) {
+	// Seconds is field 1, Nanos is field 2 on google.protobuf.Timestamp.
+	a := &timestamppb.Timestamp{Seconds: 10, Nanos: 20}
+	b := &timestamppb.Timestamp{Seconds: 11, Nanos: 20}
+
+	if Hash(a, 1) != Hash(b, 1) {
+		t.Fatalf("messages differing only in the excluded field should hash the same")
+	}
+
+	if Hash(a, 1) == Hash(&timestamppb.Timestamp{Seconds: 10, Nanos: 21}, 1) {
+		t.Fatalf("excluding field 1 should still distinguish on field 2")
+	}
+}
+
+func TestHash_LengthPrefixedNoConcatenationCollision(t *testing.T) {
+	// anypb.Any has two variable-length fields (TypeUrl, Value). Without
+	// length-prefixing, "ab"+"c" and "a"+"bc" would hash identically.
+	a := &anypb.Any{TypeUrl: "ab", Value: []byte("c")}
+	b := &anypb.Any{TypeUrl: "a", Value: []byte("bc")}
+
+	if Hash(a) == Hash(b) {
+		t.Fatalf("expected length-prefixing to prevent a concatenation collision, got equal hashes")
+	}
+}
+
+func TestHash_FieldOrderIsCanonical(t *testing.T) {
+	// Set the higher-numbered field first; the walk still visits fields in
+	// ascending field-number order, so the result matches setting them in
+	// declaration order.
+	viaNanosFirst := &timestamppb.Timestamp{}
+	viaNanosFirst.Nanos = 20
+	viaNanosFirst.Seconds = 10
+
+	viaSecondsFirst := &timestamppb.Timestamp{}
+	viaSecondsFirst.Seconds = 10
+	viaSecondsFirst.Nanos = 20
+
+	if Hash(viaNanosFirst) != Hash(viaSecondsFirst) {
+		t.Fatalf("hash should not depend on the order fields were set")
+	}
+}